@@ -0,0 +1,418 @@
+package cloudinary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ResourceListOptions narrows down a Resources() listing.
+type ResourceListOptions struct {
+	ResourceType ResourceType // Defaults to ImageType
+	Type         string       // upload, private, authenticated... defaults to "upload"
+	Prefix       string       // Only return public ids starting with Prefix
+	MaxResults   int          // Results per page, defaults to the API's own default (10)
+	Tags         bool         // Include each resource's tags in the response
+}
+
+// ResourceIterator walks the pages of a Resources() listing, fetching the
+// next page lazily as Next() is called.
+type ResourceIterator struct {
+	s         *Service
+	ctx       context.Context
+	opts      ResourceListOptions
+	page      []*Image
+	pos       int
+	cursor    string
+	fetched   bool
+	done      bool
+	err       error
+	cur       *Image
+	rateLimit *RateLimit
+}
+
+// Resources starts a paginated listing of uploaded resources. opts may be
+// nil to use the defaults (image resources, upload type).
+func (s *Service) Resources(opts *ResourceListOptions) *ResourceIterator {
+	return s.ResourcesContext(context.Background(), opts)
+}
+
+// ResourcesContext is like Resources but binds every page fetch to ctx, so
+// callers can apply deadlines or cancellation.
+func (s *Service) ResourcesContext(ctx context.Context, opts *ResourceListOptions) *ResourceIterator {
+	o := ResourceListOptions{Type: "upload"}
+	if opts != nil {
+		o = *opts
+		if o.Type == "" {
+			o.Type = "upload"
+		}
+	}
+	return &ResourceIterator{s: s, ctx: ctx, opts: o}
+}
+
+// Next advances the iterator and reports whether a resource is available
+// through Image(). It returns false at the end of the listing or on error;
+// call Err() to tell the two apart.
+func (it *ResourceIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.pos >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	it.cur = it.page[it.pos]
+	it.pos++
+	return true
+}
+
+// Image returns the resource produced by the most recent call to Next().
+func (it *ResourceIterator) Image() *Image {
+	return it.cur
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *ResourceIterator) Err() error {
+	return it.err
+}
+
+// LastRateLimit returns the rate limit reported by the most recently
+// fetched page, or nil if no page has been fetched yet.
+func (it *ResourceIterator) LastRateLimit() *RateLimit {
+	return it.rateLimit
+}
+
+func (it *ResourceIterator) fetchPage() error {
+	resType := imageType
+	if it.opts.ResourceType == RawType {
+		resType = rawType
+	}
+
+	q := url.Values{}
+	q.Set("type", it.opts.Type)
+	if it.opts.Prefix != "" {
+		q.Set("prefix", it.opts.Prefix)
+	}
+	if it.opts.MaxResults > 0 {
+		q.Set("max_results", fmt.Sprintf("%d", it.opts.MaxResults))
+	}
+	if it.opts.Tags {
+		q.Set("tags", "true")
+	}
+	if it.fetched && it.cursor != "" {
+		q.Set("next_cursor", it.cursor)
+	}
+
+	uri := fmt.Sprintf("%s/resources/%s?%s", it.s.adminURI.String(), resType, q.Encode())
+	req, err := http.NewRequestWithContext(it.ctx, "GET", uri, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := it.s.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	m, err := handleHttpResponse(resp)
+	if err != nil {
+		return err
+	}
+	it.rateLimit = parseRateLimit(resp)
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	var list imageList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return err
+	}
+
+	it.fetched = true
+	it.page = list.Resources
+	it.pos = 0
+	it.cursor = list.NextCursor
+	if it.cursor == "" {
+		it.done = true
+	}
+	return nil
+}
+
+// ResourceDetails fetches the full Image record for a single resource.
+func (s *Service) ResourceDetails(publicId string, rtype ResourceType, deliveryType string) (*Image, error) {
+	return s.ResourceDetailsContext(context.Background(), publicId, rtype, deliveryType)
+}
+
+// ResourceDetailsContext is like ResourceDetails but binds the request to
+// ctx, so callers can apply deadlines or cancellation.
+func (s *Service) ResourceDetailsContext(ctx context.Context, publicId string, rtype ResourceType, deliveryType string) (*Image, error) {
+	resType := imageType
+	if rtype == RawType {
+		resType = rawType
+	}
+	if deliveryType == "" {
+		deliveryType = "upload"
+	}
+
+	uri := fmt.Sprintf("%s/resources/%s/%s/%s", s.adminURI.String(), resType, deliveryType, publicId)
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	m, err := handleHttpResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	info := new(Image)
+	if err := json.Unmarshal(raw, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// TransformationInfo describes a named transformation as reported by the
+// Admin API's /transformations endpoint.
+type TransformationInfo struct {
+	Name string `json:"name"`
+	Used bool   `json:"used"`
+}
+
+type transformationList struct {
+	pagination
+	Transformations []*TransformationInfo `json:"transformations"`
+}
+
+// TransformationIterator walks the pages of a Transformations() listing.
+type TransformationIterator struct {
+	s         *Service
+	ctx       context.Context
+	page      []*TransformationInfo
+	pos       int
+	cursor    string
+	fetched   bool
+	done      bool
+	err       error
+	cur       *TransformationInfo
+	rateLimit *RateLimit
+}
+
+// Transformations starts a paginated listing of the named transformations
+// defined on the account.
+func (s *Service) Transformations() *TransformationIterator {
+	return s.TransformationsContext(context.Background())
+}
+
+// TransformationsContext is like Transformations but binds every page
+// fetch to ctx, so callers can apply deadlines or cancellation.
+func (s *Service) TransformationsContext(ctx context.Context) *TransformationIterator {
+	return &TransformationIterator{s: s, ctx: ctx}
+}
+
+func (it *TransformationIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.pos >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	it.cur = it.page[it.pos]
+	it.pos++
+	return true
+}
+
+// Transformation returns the item produced by the most recent call to Next().
+func (it *TransformationIterator) Transformation() *TransformationInfo {
+	return it.cur
+}
+
+func (it *TransformationIterator) Err() error {
+	return it.err
+}
+
+// LastRateLimit returns the rate limit reported by the most recently
+// fetched page, or nil if no page has been fetched yet.
+func (it *TransformationIterator) LastRateLimit() *RateLimit {
+	return it.rateLimit
+}
+
+func (it *TransformationIterator) fetchPage() error {
+	q := url.Values{}
+	if it.fetched && it.cursor != "" {
+		q.Set("next_cursor", it.cursor)
+	}
+
+	uri := fmt.Sprintf("%s/transformations?%s", it.s.adminURI.String(), q.Encode())
+	req, err := http.NewRequestWithContext(it.ctx, "GET", uri, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := it.s.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	m, err := handleHttpResponse(resp)
+	if err != nil {
+		return err
+	}
+	it.rateLimit = parseRateLimit(resp)
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	var list transformationList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return err
+	}
+
+	it.fetched = true
+	it.page = list.Transformations
+	it.pos = 0
+	it.cursor = list.NextCursor
+	if it.cursor == "" {
+		it.done = true
+	}
+	return nil
+}
+
+type tagList struct {
+	pagination
+	Tags []string `json:"tags"`
+}
+
+// TagIterator walks the pages of a Tags() listing.
+type TagIterator struct {
+	s         *Service
+	ctx       context.Context
+	prefix    string
+	page      []string
+	pos       int
+	cursor    string
+	fetched   bool
+	done      bool
+	err       error
+	cur       string
+	rateLimit *RateLimit
+}
+
+// Tags starts a paginated listing of the tags used on the account's image
+// resources; tags on raw resources are not listed. prefix
+// may be empty to list every tag.
+func (s *Service) Tags(prefix string) *TagIterator {
+	return s.TagsContext(context.Background(), prefix)
+}
+
+// TagsContext is like Tags but binds every page fetch to ctx, so callers
+// can apply deadlines or cancellation.
+func (s *Service) TagsContext(ctx context.Context, prefix string) *TagIterator {
+	return &TagIterator{s: s, ctx: ctx, prefix: prefix}
+}
+
+func (it *TagIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.pos >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	it.cur = it.page[it.pos]
+	it.pos++
+	return true
+}
+
+// Tag returns the tag produced by the most recent call to Next().
+func (it *TagIterator) Tag() string {
+	return it.cur
+}
+
+func (it *TagIterator) Err() error {
+	return it.err
+}
+
+// LastRateLimit returns the rate limit reported by the most recently
+// fetched page, or nil if no page has been fetched yet.
+func (it *TagIterator) LastRateLimit() *RateLimit {
+	return it.rateLimit
+}
+
+func (it *TagIterator) fetchPage() error {
+	q := url.Values{}
+	if it.prefix != "" {
+		q.Set("prefix", it.prefix)
+	}
+	if it.fetched && it.cursor != "" {
+		q.Set("next_cursor", it.cursor)
+	}
+
+	uri := fmt.Sprintf("%s/tags/%s?%s", it.s.adminURI.String(), imageType, q.Encode())
+	req, err := http.NewRequestWithContext(it.ctx, "GET", uri, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := it.s.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	m, err := handleHttpResponse(resp)
+	if err != nil {
+		return err
+	}
+	it.rateLimit = parseRateLimit(resp)
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	var list tagList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return err
+	}
+
+	it.fetched = true
+	it.page = list.Tags
+	it.pos = 0
+	it.cursor = list.NextCursor
+	if it.cursor == "" {
+		it.done = true
+	}
+	return nil
+}
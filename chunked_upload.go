@@ -0,0 +1,281 @@
+package cloudinary
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default size of a single chunk when UploadOptions.ChunkSize is not set.
+const defaultChunkSize = 20 << 20 // 20MB
+
+// Number of attempts made for a single chunk upload before giving up.
+const chunkMaxRetries = 3
+
+// UploadOptions configures an Upload, UploadContext or UploadLarge call.
+type UploadOptions struct {
+	// ChunkSize is the size in bytes of each chunk uploaded by UploadLarge.
+	// Cloudinary accepts chunks between 5MB and 20MB; if zero,
+	// defaultChunkSize is used. Ignored by Upload.
+	ChunkSize int64
+
+	// EagerTransformations are applied to the upload as soon as it
+	// completes (or, if EagerAsync is set, in the background).
+	EagerTransformations []*Transformation
+	// EagerAsync runs EagerTransformations in the background; completion
+	// is reported to NotificationURL.
+	EagerAsync bool
+	// NotificationURL receives a webhook once eager transformations
+	// started with EagerAsync finish. See Service.HandleWebhook.
+	NotificationURL string
+	// Tags are attached to the uploaded resource.
+	Tags []string
+	// Context is stored as structured metadata on the uploaded resource.
+	Context map[string]string
+	// Folder places the upload under a folder in the Cloudinary media
+	// library.
+	Folder string
+	// Overwrite controls whether uploading to an existing public id
+	// replaces it. Nil leaves Cloudinary's own default (true) in effect.
+	Overwrite *bool
+}
+
+// uniqueUploadId generates the random token sent as X-Unique-Upload-Id to
+// tie a series of chunk uploads together.
+func uniqueUploadId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// UploadLarge uploads path in chunks of opts.ChunkSize bytes using
+// Cloudinary's resumable upload protocol: every chunk carries the same
+// X-Unique-Upload-Id and a Content-Range header, and the final chunk's
+// response carries the resulting resource. opts is honored the same way
+// uploadFile honors it: EagerTransformations, EagerAsync, NotificationURL,
+// Tags, Context, Folder and Overwrite are all signed and sent with every
+// chunk, so a retried or slow multi-chunk upload always presents the same
+// signed identity.
+//
+// Unlike uploadFile, which buffers the whole file in memory, UploadLarge
+// streams each chunk straight from disk so multi-gigabyte assets don't
+// have to fit in RAM.
+func (s *Service) UploadLarge(path string, randomPublicId bool, opts UploadOptions) (*Image, error) {
+	return s.UploadLargeContext(context.Background(), path, randomPublicId, opts)
+}
+
+// UploadLargeContext is like UploadLarge but binds every chunk request to
+// ctx, so callers can apply deadlines or cancellation.
+func (s *Service) UploadLargeContext(ctx context.Context, path string, randomPublicId bool, opts UploadOptions) (*Image, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	fi, err := fd.Stat()
+	if err != nil {
+		return nil, err
+	}
+	total := fi.Size()
+
+	// Every chunk must carry the exact same signed params, so compute the
+	// public id, timestamp and signature once up front rather than per
+	// chunk (or per retry).
+	params := map[string]string{}
+	var publicId string
+	if !randomPublicId {
+		publicId = cleanAssetName(path)
+		params["public_id"] = publicId
+	}
+	params["timestamp"] = strconv.FormatInt(time.Now().Unix(), 10)
+	if len(opts.EagerTransformations) > 0 {
+		eager := make([]string, len(opts.EagerTransformations))
+		for i, t := range opts.EagerTransformations {
+			eager[i] = t.String()
+		}
+		params["eager"] = strings.Join(eager, "|")
+	}
+	if opts.EagerAsync {
+		params["eager_async"] = "true"
+	}
+	if opts.NotificationURL != "" {
+		params["notification_url"] = opts.NotificationURL
+	}
+	if len(opts.Tags) > 0 {
+		params["tags"] = strings.Join(opts.Tags, ",")
+	}
+	if len(opts.Context) > 0 {
+		params["context"] = encodeContext(opts.Context)
+	}
+	if opts.Folder != "" {
+		params["folder"] = opts.Folder
+	}
+	if opts.Overwrite != nil {
+		params["overwrite"] = strconv.FormatBool(*opts.Overwrite)
+	}
+	signature := signParams(params, s.apiSecret)
+
+	fileName := publicId
+	if fileName == "" {
+		fileName = filepath.Base(path)
+	}
+
+	uploadId, err := uniqueUploadId()
+	if err != nil {
+		return nil, err
+	}
+
+	upURI := s.uploadURI.String()
+	if s.uploadResType == RawType {
+		upURI = strings.Replace(upURI, imageType, rawType, 1)
+	}
+
+	var upInfo *uploadResponse
+	for start := int64(0); start < total; start += chunkSize {
+		end := start + chunkSize
+		if end > total {
+			end = total
+		}
+
+		chunk := make([]byte, end-start)
+		if _, err := io.ReadFull(fd, chunk); err != nil {
+			return nil, err
+		}
+
+		resp, err := s.uploadChunk(ctx, upURI, params, signature, fileName, uploadId, chunk, start, end, total)
+		if err != nil {
+			return nil, err
+		}
+		upInfo = resp
+	}
+
+	if upInfo == nil {
+		return nil, errors.New("UploadLarge: empty file")
+	}
+
+	return &Image{
+		PublicId:     upInfo.PublicId,
+		Format:       upInfo.Format,
+		Version:      int(upInfo.Version),
+		ResourceType: upInfo.ResourceType,
+	}, nil
+}
+
+// uploadChunk uploads a single chunk, retrying with a linear backoff on
+// any error. params and signature are fixed for the whole upload;
+// they are not recomputed between chunks or retries.
+func (s *Service) uploadChunk(ctx context.Context, upURI string, params map[string]string, signature, fileName, uploadId string, chunk []byte, start, end, total int64) (*uploadResponse, error) {
+	var lastErr error
+	for attempt := 0; attempt < chunkMaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+
+		resp, err := s.doUploadChunk(ctx, upURI, params, signature, fileName, uploadId, chunk, start, end, total)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (s *Service) doUploadChunk(ctx context.Context, upURI string, params map[string]string, signature, fileName, uploadId string, chunk []byte, start, end, total int64) (*uploadResponse, error) {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+
+	go func() {
+		var err error
+		defer func() {
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			pw.Close()
+		}()
+
+		for key, val := range params {
+			var field io.Writer
+			if field, err = w.CreateFormField(key); err != nil {
+				return
+			}
+			if _, err = field.Write([]byte(val)); err != nil {
+				return
+			}
+		}
+
+		var ak io.Writer
+		if ak, err = w.CreateFormField("api_key"); err != nil {
+			return
+		}
+		if _, err = ak.Write([]byte(s.apiKey)); err != nil {
+			return
+		}
+
+		var si io.Writer
+		if si, err = w.CreateFormField("signature"); err != nil {
+			return
+		}
+		if _, err = si.Write([]byte(signature)); err != nil {
+			return
+		}
+
+		var fw io.Writer
+		if fw, err = w.CreateFormFile("file", fileName); err != nil {
+			return
+		}
+		if _, err = fw.Write(chunk); err != nil {
+			return
+		}
+
+		err = w.Close()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", upURI, pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("X-Unique-Upload-Id", uploadId)
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, total))
+
+	resp, err := s.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIErrorFromResponse(resp)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	upInfo := new(uploadResponse)
+	if err := dec.Decode(upInfo); err != nil {
+		return nil, err
+	}
+	return upInfo, nil
+}
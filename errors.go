@@ -0,0 +1,68 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError is returned whenever the Cloudinary API responds with a
+// non-200 status. StatusCode and Message let callers branch on e.g. 404
+// (not found) vs 420 (rate limited) vs 5xx (server error); Raw holds the
+// full decoded JSON error body for anything the typed fields don't cover.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Raw        map[string]interface{}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("cloudinary: %s (status %d)", e.Message, e.StatusCode)
+}
+
+// RateLimit reports the account-level rate limit state returned on the
+// X-FeatureRateLimit-* headers of a successful API call.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// parseRateLimit extracts a RateLimit from resp's headers, or returns nil
+// if the response carried none (older endpoints don't set them).
+func parseRateLimit(resp *http.Response) *RateLimit {
+	limit := resp.Header.Get("X-FeatureRateLimit-Limit")
+	remaining := resp.Header.Get("X-FeatureRateLimit-Remaining")
+	reset := resp.Header.Get("X-FeatureRateLimit-Reset")
+	if limit == "" && remaining == "" && reset == "" {
+		return nil
+	}
+
+	rl := &RateLimit{}
+	rl.Limit, _ = strconv.Atoi(limit)
+	rl.Remaining, _ = strconv.Atoi(remaining)
+	if t, err := time.Parse(time.RFC1123, reset); err == nil {
+		rl.Reset = t
+	}
+	return rl
+}
+
+// newAPIErrorFromResponse builds an APIError from a non-200 response,
+// decoding its JSON body (if any) into Raw and pulling the "error.message"
+// field Cloudinary sets, e.g. {"error":{"message":"..."}}.
+func newAPIErrorFromResponse(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: resp.Status}
+
+	var raw map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err == nil {
+		apiErr.Raw = raw
+		if e, ok := raw["error"].(map[string]interface{}); ok {
+			if msg, ok := e["message"].(string); ok {
+				apiErr.Message = msg
+			}
+		}
+	}
+	return apiErr
+}
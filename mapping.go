@@ -1,13 +1,15 @@
 package cloudinary
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
 type ListUploadMappingsOutput struct {
-	Mappings []Mapping `json:"mappings"`
+	Mappings  []Mapping `json:"mappings"`
+	RateLimit *RateLimit
 }
 
 type Mapping struct {
@@ -16,23 +18,34 @@ type Mapping struct {
 }
 
 func (s *Service) ListUploadMappings() (*ListUploadMappingsOutput, error) {
+	return s.ListUploadMappingsContext(context.Background())
+}
+
+// ListUploadMappingsContext is like ListUploadMappings but binds the
+// request to ctx, so callers can apply deadlines or cancellation.
+func (s *Service) ListUploadMappingsContext(ctx context.Context) (*ListUploadMappingsOutput, error) {
 	uri := fmt.Sprintf("%s/upload_mappings", s.adminURI.String())
-	req, err := http.NewRequest("GET", uri, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client().Do(req)
 	if err != nil {
 		return nil, err
 	}
-
 	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIErrorFromResponse(resp)
+	}
+
 	dec := json.NewDecoder(resp.Body)
 	output := &ListUploadMappingsOutput{}
 	if err := dec.Decode(output); err != nil {
 		return nil, err
 	}
+	output.RateLimit = parseRateLimit(resp)
 
 	return output, nil
 }
@@ -42,10 +55,17 @@ type CreateUploadMappingInput struct {
 }
 
 type CreateUploadMappingOutput struct {
-	Message string `json:"message"`
+	Message   string `json:"message"`
+	RateLimit *RateLimit
 }
 
 func (s *Service) CreateUploadMapping(input *CreateUploadMappingInput) (*CreateUploadMappingOutput, error) {
+	return s.CreateUploadMappingContext(context.Background(), input)
+}
+
+// CreateUploadMappingContext is like CreateUploadMapping but binds the
+// request to ctx, so callers can apply deadlines or cancellation.
+func (s *Service) CreateUploadMappingContext(ctx context.Context, input *CreateUploadMappingInput) (*CreateUploadMappingOutput, error) {
 	uri := fmt.Sprintf(
 		"%s/upload_mappings?folder=%s&template=%s",
 		s.adminURI.String(),
@@ -53,22 +73,27 @@ func (s *Service) CreateUploadMapping(input *CreateUploadMappingInput) (*CreateU
 		input.Mapping.Template,
 	)
 
-	req, err := http.NewRequest("POST", uri, nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", uri, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client().Do(req)
 	if err != nil {
 		return nil, err
 	}
-
 	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIErrorFromResponse(resp)
+	}
+
 	dec := json.NewDecoder(resp.Body)
 	output := &CreateUploadMappingOutput{}
 	if err := dec.Decode(output); err != nil {
 		return nil, err
 	}
+	output.RateLimit = parseRateLimit(resp)
 
 	return output, nil
 }
@@ -78,32 +103,44 @@ type DeleteUploadMappingInput struct {
 }
 
 type DeleteUploadMappingOutput struct {
-	Message string `json:"message"`
+	Message   string `json:"message"`
+	RateLimit *RateLimit
 }
 
 func (s *Service) DeleteUploadMapping(input *DeleteUploadMappingInput) (*DeleteUploadMappingOutput, error) {
+	return s.DeleteUploadMappingContext(context.Background(), input)
+}
+
+// DeleteUploadMappingContext is like DeleteUploadMapping but binds the
+// request to ctx, so callers can apply deadlines or cancellation.
+func (s *Service) DeleteUploadMappingContext(ctx context.Context, input *DeleteUploadMappingInput) (*DeleteUploadMappingOutput, error) {
 	uri := fmt.Sprintf(
 		"%s/upload_mappings?folder=%s",
 		s.adminURI.String(),
 		input.Folder,
 	)
 
-	req, err := http.NewRequest("DELETE", uri, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", uri, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client().Do(req)
 	if err != nil {
 		return nil, err
 	}
-
 	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIErrorFromResponse(resp)
+	}
+
 	dec := json.NewDecoder(resp.Body)
 	output := &DeleteUploadMappingOutput{}
 	if err := dec.Decode(output); err != nil {
 		return nil, err
 	}
+	output.RateLimit = parseRateLimit(resp)
 
 	return output, nil
 }
@@ -0,0 +1,257 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+// ErrNotFound is returned by a MetadataStore when no entry matches the
+// requested public id or checksum.
+var ErrNotFound = errors.New("cloudinary: no such entry in metadata store")
+
+// MetadataStore persists the Image returned by a successful upload so that
+// Url() can fill in a public id's version and format without round-tripping
+// to the Cloudinary API, Delete() can drop the recorded entry, and
+// uploadFile can detect that a source file was already uploaded by
+// comparing its checksum.
+type MetadataStore interface {
+	// Put records info under publicId. info.Checksum, when set, is also
+	// indexed so HasChecksum can find it later.
+	Put(publicId string, info *Image) error
+
+	// Get returns the Image previously stored for publicId, or ErrNotFound.
+	Get(publicId string) (*Image, error)
+
+	// HasChecksum looks up a previously uploaded file by the SHA1 checksum
+	// of its contents. It returns the public id it was stored under and
+	// true if found.
+	HasChecksum(sum string) (string, bool, error)
+
+	// Delete removes any entry stored for publicId.
+	Delete(publicId string) error
+}
+
+// SetMetadataStore registers store as the place upload metadata is
+// persisted to. When unset (the default), Upload still works but Url()
+// only uses the Version and Format given in its options, and repeated
+// uploads of identical files are not deduplicated.
+func (s *Service) SetMetadataStore(store MetadataStore) {
+	s.metadataStore = store
+}
+
+// InMemoryMetadataStore is a MetadataStore backed by an in-process map. It
+// does not survive restarts and is mainly useful for tests and small
+// single-process programs.
+type InMemoryMetadataStore struct {
+	mu        sync.RWMutex
+	images    map[string]*Image
+	checksums map[string]string // checksum -> public id
+}
+
+// NewInMemoryMetadataStore returns a ready to use InMemoryMetadataStore.
+func NewInMemoryMetadataStore() *InMemoryMetadataStore {
+	return &InMemoryMetadataStore{
+		images:    make(map[string]*Image),
+		checksums: make(map[string]string),
+	}
+}
+
+func (m *InMemoryMetadataStore) Put(publicId string, info *Image) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.images[publicId] = info
+	if info.Checksum != "" {
+		m.checksums[info.Checksum] = publicId
+	}
+	return nil
+}
+
+func (m *InMemoryMetadataStore) Get(publicId string) (*Image, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	info, ok := m.images[publicId]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return info, nil
+}
+
+func (m *InMemoryMetadataStore) HasChecksum(sum string) (string, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	publicId, ok := m.checksums[sum]
+	return publicId, ok, nil
+}
+
+func (m *InMemoryMetadataStore) Delete(publicId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if info, ok := m.images[publicId]; ok && info.Checksum != "" {
+		delete(m.checksums, info.Checksum)
+	}
+	delete(m.images, publicId)
+	return nil
+}
+
+// MongoMetadataStore persists upload metadata in a MongoDB collection,
+// indexed by public id and checksum.
+type MongoMetadataStore struct {
+	session *mgo.Session
+	coll    *mgo.Collection
+}
+
+// NewMongoMetadataStore dials uri (a mongodb:// URI, as accepted by
+// UseDatabase) and stores documents in database db, collection coll.
+func NewMongoMetadataStore(uri, db, coll string) (*MongoMetadataStore, error) {
+	session, err := mgo.Dial(uri)
+	if err != nil {
+		return nil, err
+	}
+	c := session.DB(db).C(coll)
+	if err := c.EnsureIndexKey("publicid"); err != nil {
+		session.Close()
+		return nil, err
+	}
+	if err := c.EnsureIndexKey("checksum"); err != nil {
+		session.Close()
+		return nil, err
+	}
+	return &MongoMetadataStore{session: session, coll: c}, nil
+}
+
+type mongoDoc struct {
+	PublicId string `bson:"publicid"`
+	Checksum string `bson:"checksum"`
+	Image    *Image `bson:"image"`
+}
+
+func (m *MongoMetadataStore) Put(publicId string, info *Image) error {
+	_, err := m.coll.Upsert(bson.M{"publicid": publicId}, bson.M{"$set": &mongoDoc{
+		PublicId: publicId,
+		Checksum: info.Checksum,
+		Image:    info,
+	}})
+	return err
+}
+
+func (m *MongoMetadataStore) Get(publicId string) (*Image, error) {
+	var doc mongoDoc
+	if err := m.coll.Find(bson.M{"publicid": publicId}).One(&doc); err != nil {
+		if err == mgo.ErrNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.Image, nil
+}
+
+func (m *MongoMetadataStore) HasChecksum(sum string) (string, bool, error) {
+	var doc mongoDoc
+	err := m.coll.Find(bson.M{"checksum": sum}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return doc.PublicId, true, nil
+}
+
+func (m *MongoMetadataStore) Delete(publicId string) error {
+	err := m.coll.Remove(bson.M{"publicid": publicId})
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// RedisMetadataStore persists upload metadata in Redis. Images are stored
+// as JSON strings under an "cloudinary:image:<publicId>" key, with a
+// companion "cloudinary:checksum:<sum>" key pointing back at the public id.
+type RedisMetadataStore struct {
+	pool *redis.Pool
+}
+
+// NewRedisMetadataStore wraps an existing redis.Pool.
+func NewRedisMetadataStore(pool *redis.Pool) *RedisMetadataStore {
+	return &RedisMetadataStore{pool: pool}
+}
+
+func (r *RedisMetadataStore) imageKey(publicId string) string { return "cloudinary:image:" + publicId }
+func (r *RedisMetadataStore) checksumKey(sum string) string   { return "cloudinary:checksum:" + sum }
+
+func (r *RedisMetadataStore) Put(publicId string, info *Image) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	raw, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Do("SET", r.imageKey(publicId), raw); err != nil {
+		return err
+	}
+	if info.Checksum != "" {
+		if _, err := conn.Do("SET", r.checksumKey(info.Checksum), publicId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RedisMetadataStore) Get(publicId string) (*Image, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	raw, err := redis.Bytes(conn.Do("GET", r.imageKey(publicId)))
+	if err == redis.ErrNil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info := new(Image)
+	if err := json.Unmarshal(raw, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (r *RedisMetadataStore) HasChecksum(sum string) (string, bool, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	publicId, err := redis.String(conn.Do("GET", r.checksumKey(sum)))
+	if err == redis.ErrNil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return publicId, true, nil
+}
+
+func (r *RedisMetadataStore) Delete(publicId string) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	info, err := r.Get(publicId)
+	if err != nil && err != ErrNotFound {
+		return err
+	}
+	if _, err := conn.Do("DEL", r.imageKey(publicId)); err != nil {
+		return err
+	}
+	if info != nil && info.Checksum != "" {
+		if _, err := conn.Do("DEL", r.checksumKey(info.Checksum)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
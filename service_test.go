@@ -0,0 +1,74 @@
+package cloudinary
+
+import "testing"
+
+func TestSignParams(t *testing.T) {
+	cases := []struct {
+		name      string
+		params    map[string]string
+		apiSecret string
+		want      string
+	}{
+		{
+			name:      "single param",
+			params:    map[string]string{"public_id": "logo"},
+			apiSecret: "secret",
+			want:      "55ff982ee25f622d8168f8d93a38afe8840a9fce",
+		},
+		{
+			name:      "multiple params are sorted before signing",
+			params:    map[string]string{"timestamp": "123", "public_id": "logo"},
+			apiSecret: "secret",
+			want:      "75ec60f7c53350c268f7573d75ee2ef5f51f80d6",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := signParams(c.params, c.apiSecret); got != c.want {
+				t.Fatalf("signParams(%v, %q) = %q, want %q", c.params, c.apiSecret, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSignParamsKeyOrderIndependent(t *testing.T) {
+	a := signParams(map[string]string{"public_id": "logo", "timestamp": "123"}, "secret")
+	b := signParams(map[string]string{"timestamp": "123", "public_id": "logo"}, "secret")
+	if a != b {
+		t.Fatalf("signParams should not depend on map iteration order: got %q and %q", a, b)
+	}
+}
+
+func TestSignParamsDifferentSecret(t *testing.T) {
+	params := map[string]string{"public_id": "logo"}
+	a := signParams(params, "secret")
+	b := signParams(params, "other-secret")
+	if a == b {
+		t.Fatalf("signParams(%v, ...) should differ between secrets, both got %q", params, a)
+	}
+}
+
+func TestEncodeContext(t *testing.T) {
+	cases := []struct {
+		name    string
+		context map[string]string
+		want    string
+	}{
+		{name: "empty", context: nil, want: ""},
+		{name: "single pair", context: map[string]string{"alt": "logo"}, want: "alt=logo"},
+		{
+			name:    "sorted by key regardless of insertion order",
+			context: map[string]string{"caption": "hi", "alt": "logo"},
+			want:    "alt=logo|caption=hi",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := encodeContext(c.context); got != c.want {
+				t.Fatalf("encodeContext(%v) = %q, want %q", c.context, got, c.want)
+			}
+		})
+	}
+}
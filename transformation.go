@@ -0,0 +1,87 @@
+package cloudinary
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Transformation builds a Cloudinary transformation string, e.g.
+// "w_400,h_300,c_fill/e_grayscale". Use NewTransformation and its fluent
+// methods to compose one, calling Chain to start a new comma-separated
+// segment joined to the previous one with "/".
+type Transformation struct {
+	segments [][]string
+}
+
+// NewTransformation returns an empty Transformation ready for chaining.
+func NewTransformation() *Transformation {
+	return &Transformation{segments: [][]string{nil}}
+}
+
+func (t *Transformation) param(key, value string) *Transformation {
+	last := len(t.segments) - 1
+	t.segments[last] = append(t.segments[last], key+"_"+value)
+	return t
+}
+
+// Width sets the "w" parameter on the current segment.
+func (t *Transformation) Width(w int) *Transformation {
+	return t.param("w", strconv.Itoa(w))
+}
+
+// Height sets the "h" parameter on the current segment.
+func (t *Transformation) Height(h int) *Transformation {
+	return t.param("h", strconv.Itoa(h))
+}
+
+// Crop sets the "c" parameter (e.g. "fill", "fit", "crop") on the current
+// segment.
+func (t *Transformation) Crop(mode string) *Transformation {
+	return t.param("c", mode)
+}
+
+// Effect sets the "e" parameter (e.g. "grayscale", "sepia") on the current
+// segment.
+func (t *Transformation) Effect(effect string) *Transformation {
+	return t.param("e", effect)
+}
+
+// Overlay sets the "l" parameter to the public id of the resource to
+// overlay on the current segment.
+func (t *Transformation) Overlay(publicId string) *Transformation {
+	return t.param("l", publicId)
+}
+
+// Quality sets the "q" parameter (e.g. "80", "auto") on the current
+// segment.
+func (t *Transformation) Quality(quality string) *Transformation {
+	return t.param("q", quality)
+}
+
+// FetchFormat sets the "f" parameter (e.g. "auto", "webp") on the current
+// segment.
+func (t *Transformation) FetchFormat(format string) *Transformation {
+	return t.param("f", format)
+}
+
+// Chain closes the current segment and starts a new one, producing a
+// chained transformation ("seg1/seg2/...") once String is called.
+func (t *Transformation) Chain() *Transformation {
+	t.segments = append(t.segments, nil)
+	return t
+}
+
+// String renders the transformation as Cloudinary's URL syntax.
+func (t *Transformation) String() string {
+	if t == nil {
+		return ""
+	}
+	segments := make([]string, 0, len(t.segments))
+	for _, seg := range t.segments {
+		if len(seg) == 0 {
+			continue
+		}
+		segments = append(segments, strings.Join(seg, ","))
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,37 @@
+package cloudinary
+
+import "testing"
+
+func TestTransformationString(t *testing.T) {
+	cases := []struct {
+		name string
+		t    *Transformation
+		want string
+	}{
+		{name: "nil transformation", t: nil, want: ""},
+		{name: "empty transformation", t: NewTransformation(), want: ""},
+		{
+			name: "single segment",
+			t:    NewTransformation().Width(100).Height(150).Crop("fill"),
+			want: "w_100,h_150,c_fill",
+		},
+		{
+			name: "chained segments",
+			t:    NewTransformation().Width(100).Height(150).Crop("fill").Chain().Effect("grayscale"),
+			want: "w_100,h_150,c_fill/e_grayscale",
+		},
+		{
+			name: "empty segment from a trailing Chain is skipped",
+			t:    NewTransformation().Width(100).Chain(),
+			want: "w_100",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.t.String(); got != c.want {
+				t.Fatalf("Transformation.String() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package cloudinary
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UrlOptions controls how Service.Url builds a delivery URL for a public id.
+type UrlOptions struct {
+	ResourceType   ResourceType // Defaults to ImageType
+	Type           string       // upload, private, authenticated, fetch... defaults to "upload"
+	Version        int          // Prefixed to the path as "v<version>" when non-zero
+	Format         string       // Appended as the file extension when non-empty
+	Secure         bool         // Use https instead of http
+	CName          string       // Custom CNAME to serve from instead of *.cloudinary.com
+	PrivateCDN     bool         // Serve from "<cloud_name>-res.cloudinary.com" instead of the shared CDN
+	ForceVersion   bool         // Always include a version segment, generating "v1" if Version is unset
+	Sign           bool         // Prefix the path with a signed "s--xxxxxxxx--" token
+	Transformation *Transformation
+}
+
+// Url returns the complete access path in the cloud to the resource
+// designed by publicId. When
+// opts omits Version or Format and a MetadataStore is set, Url falls back
+// to the info recorded for publicId at upload time.
+func (s *Service) Url(publicId string, opts *UrlOptions) string {
+	var o UrlOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Type == "" {
+		o.Type = "upload"
+	}
+
+	if s.metadataStore != nil && (o.Version == 0 || o.Format == "") {
+		if info, err := s.metadataStore.Get(publicId); err == nil {
+			if o.Version == 0 {
+				o.Version = info.Version
+			}
+			if o.Format == "" {
+				o.Format = info.Format
+			}
+		}
+	}
+
+	resType := imageType
+	if o.ResourceType == RawType {
+		resType = rawType
+	}
+
+	scheme := "http"
+	if o.Secure {
+		scheme = "https"
+	}
+
+	host := o.CName
+	if host == "" {
+		if o.PrivateCDN {
+			host = fmt.Sprintf("%s-res.cloudinary.com", s.cloudName)
+		} else {
+			host = "res.cloudinary.com"
+		}
+	}
+
+	parts := []string{resType, o.Type}
+	if !o.PrivateCDN && o.CName == "" {
+		parts = append([]string{s.cloudName}, parts...)
+	}
+
+	transformation := o.Transformation.String()
+
+	if o.Sign {
+		parts = append(parts, signaturePrefix(transformation, publicId, s.apiSecret))
+	}
+
+	if transformation != "" {
+		parts = append(parts, transformation)
+	}
+
+	if o.Version != 0 {
+		parts = append(parts, "v"+strconv.Itoa(o.Version))
+	} else if o.ForceVersion {
+		parts = append(parts, "v1")
+	}
+
+	file := publicId
+	if o.Format != "" {
+		file = fmt.Sprintf("%s.%s", publicId, o.Format)
+	}
+	parts = append(parts, file)
+
+	return fmt.Sprintf("%s://%s/%s", scheme, host, strings.Join(parts, "/"))
+}
+
+// signaturePrefix computes the "s--xxxxxxxx--" token Cloudinary expects
+// when a delivery URL's Type requires a signed path.
+func signaturePrefix(transformation, publicId, apiSecret string) string {
+	toSign := publicId
+	if transformation != "" {
+		toSign = transformation + "/" + publicId
+	}
+	hash := sha1.New()
+	hash.Write([]byte(toSign + apiSecret))
+	sum := base64.RawURLEncoding.EncodeToString(hash.Sum(nil))
+	return fmt.Sprintf("s--%s--", sum[:8])
+}
@@ -0,0 +1,64 @@
+package cloudinary
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// NotificationPayload is the JSON body Cloudinary posts to a notification
+// webhook, e.g. on completion of an eager_async transformation started by
+// UploadOptions.EagerAsync.
+type NotificationPayload struct {
+	NotificationType string   `json:"notification_type"`
+	Timestamp        string   `json:"timestamp"`
+	PublicId         string   `json:"public_id"`
+	Version          int      `json:"version"`
+	ResourceType     string   `json:"resource_type"`
+	Eager            []*Image `json:"eager"`
+}
+
+// HandleWebhook returns an http.Handler that verifies the
+// X-Cld-Signature/X-Cld-Timestamp headers Cloudinary sends with every
+// notification (SHA1 of the raw request body, the timestamp header, and
+// secret), decodes the JSON payload, and calls next with it. Requests that
+// fail verification or fail to parse get a 401/400 and next is not called.
+func (s *Service) HandleWebhook(secret string, next func(*NotificationPayload)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp := r.Header.Get("X-Cld-Timestamp")
+		signature := r.Header.Get("X-Cld-Signature")
+		if timestamp == "" || signature == "" {
+			http.Error(w, "missing X-Cld-Timestamp/X-Cld-Signature headers", http.StatusBadRequest)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		hash := sha1.New()
+		hash.Write(body)
+		io.WriteString(hash, timestamp+secret)
+		expected := hash.Sum(nil)
+
+		got, err := hex.DecodeString(signature)
+		if err != nil || !hmac.Equal(expected, got) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var payload NotificationPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		next(&payload)
+		w.WriteHeader(http.StatusOK)
+	})
+}
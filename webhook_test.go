@@ -0,0 +1,99 @@
+package cloudinary
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sign(secret, timestamp, body string) string {
+	hash := sha1.New()
+	hash.Write([]byte(body))
+	hash.Write([]byte(timestamp + secret))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func TestHandleWebhook(t *testing.T) {
+	const secret = "webhook-secret"
+	const timestamp = "1700000000"
+	const body = `{"notification_type":"eager","public_id":"logo","version":1,"resource_type":"image"}`
+
+	cases := []struct {
+		name       string
+		timestamp  string
+		signature  string
+		body       string
+		wantStatus int
+		wantCalled bool
+	}{
+		{
+			name:       "valid signature",
+			timestamp:  timestamp,
+			signature:  sign(secret, timestamp, body),
+			body:       body,
+			wantStatus: http.StatusOK,
+			wantCalled: true,
+		},
+		{
+			name:       "wrong signature",
+			timestamp:  timestamp,
+			signature:  sign("wrong-secret", timestamp, body),
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+			wantCalled: false,
+		},
+		{
+			name:       "tampered body",
+			timestamp:  timestamp,
+			signature:  sign(secret, timestamp, body),
+			body:       strings.Replace(body, "logo", "other", 1),
+			wantStatus: http.StatusUnauthorized,
+			wantCalled: false,
+		},
+		{
+			name:       "non-hex signature",
+			timestamp:  timestamp,
+			signature:  "not-hex",
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+			wantCalled: false,
+		},
+		{
+			name:       "missing timestamp",
+			timestamp:  "",
+			signature:  sign(secret, timestamp, body),
+			body:       body,
+			wantStatus: http.StatusBadRequest,
+			wantCalled: false,
+		},
+	}
+
+	s := testService(t)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var called bool
+			handler := s.HandleWebhook(secret, func(p *NotificationPayload) {
+				called = true
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(c.body))
+			if c.timestamp != "" {
+				req.Header.Set("X-Cld-Timestamp", c.timestamp)
+			}
+			req.Header.Set("X-Cld-Signature", c.signature)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != c.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, c.wantStatus)
+			}
+			if called != c.wantCalled {
+				t.Fatalf("next called = %v, want %v", called, c.wantCalled)
+			}
+		})
+	}
+}